@@ -1,47 +1,35 @@
 package main
 
 import (
+	"flag"
 	"github.com/gdamore/tcell/v2"
 	"log"
-	"math"
+	"spaceship/obj"
+	"spaceship/render"
+	"spaceship/sim"
 	"spaceship/vector"
 	"time"
 )
 
 type GameState struct {
-	vertices []vector.Vec3
-	angle    float64
-	player   Player
-}
-
-type Player struct {
-	Position   vector.Vec3
-	Velocity   vector.Vec3
-	Yaw        float64
-	Pitch      float64
-	prevMouseX int
-	prevMouseY int
-	firstMouse bool
-}
-
-func (p *Player) Forward() vector.Vec3 {
-	yaw := p.Yaw
-	pitch := p.Pitch
-	x := -math.Sin(yaw) * math.Cos(pitch)
-	y := math.Sin(pitch)
-	z := -math.Cos(yaw) * math.Cos(pitch)
-	return vector.Vec3{X: x, Y: y, Z: z}
+	world *sim.World
 }
 
 const fov = 90.0
-const friction = 0.99
 const thrust = 0.005
 const brakeForce = 0.05
+const bulletSpeed = 0.6
+
+// sunLight is the scene's single directional light, used to flat-shade
+// filled triangles in the (default) rasterized render mode.
+var sunLight = render.Light{
+	Direction: vector.Vec3{X: -0.4, Y: -1, Z: -0.3}.Normalize(),
+	Intensity: 1.0,
+}
 
+// Update advances the simulation by one fixed timestep.
 func (gs *GameState) Update() {
-	gs.angle += 0.01
-	gs.player.Velocity = gs.player.Velocity.Scale(friction)
-	gs.player.Position = gs.player.Position.Add(gs.player.Velocity)
+	gs.world.Step(sim.FixedDT)
 }
 
 func drawLine(screen tcell.Screen, x1, y1, x2, y2 int, style tcell.Style) {
@@ -79,6 +67,21 @@ func drawLine(screen tcell.Screen, x1, y1, x2, y2 int, style tcell.Style) {
 }
 
 func main() {
+	modelPath := flag.String("model", "", "path to a Wavefront .obj model to fly around (defaults to a cube)")
+	wire := flag.Bool("wire", false, "draw wireframe triangles instead of filled, shaded ones")
+	flag.Parse()
+
+	var meshes []obj.Mesh
+	if *modelPath != "" {
+		m, err := obj.Load(*modelPath)
+		if err != nil {
+			log.Fatalf("failed to load model %q: %v", *modelPath, err)
+		}
+		meshes = append(meshes, m)
+	} else {
+		meshes = append(meshes, obj.DefaultCube())
+	}
+
 	screen, err := tcell.NewScreen()
 	if err != nil {
 		log.Fatalf("failed to create screen: %v", err)
@@ -90,23 +93,9 @@ func main() {
 
 	defer screen.Fini()
 
-	verts := []vector.Vec3{
-		{X: -1, Y: -1, Z: -1}, {X: 1, Y: -1, Z: -1}, {X: 1, Y: 1, Z: -1}, {X: -1, Y: 1, Z: -1},
-		{X: -1, Y: -1, Z: 1}, {X: 1, Y: -1, Z: 1}, {X: 1, Y: 1, Z: 1}, {X: -1, Y: 1, Z: 1},
-	}
-	triangles := [][3]int{
-		{0, 2, 1}, {0, 3, 2}, // Front face
-		{1, 2, 6}, {1, 6, 5}, // Right face
-		{0, 1, 5}, {0, 5, 4}, // Top face
-		{3, 7, 6}, {3, 6, 2}, // Bottom face
-		{0, 4, 7}, {0, 7, 3}, // Left face
-		{4, 5, 6}, {4, 6, 7}, // Back face
-	}
-
+	player := sim.NewPlayer(vector.Vec3{X: 0, Y: 0, Z: 5})
 	gs := &GameState{
-		vertices: verts,
-		angle:    0,
-		player:   Player{Position: vector.Vec3{X: 0, Y: 0, Z: 5}, firstMouse: true},
+		world: sim.NewWorld(player, meshes),
 	}
 
 	quit := make(chan struct{})
@@ -120,120 +109,159 @@ func main() {
 					close(quit)
 					return
 				case 'a':
-					gs.player.Yaw += 0.03
+					player.Turn(0.03, 0)
 				case 'd':
-					gs.player.Yaw -= 0.03
+					player.Turn(-0.03, 0)
 				case 'r':
-					gs.player.Pitch += 0.03
+					player.Turn(0, 0.03)
 				case 'f':
-					gs.player.Pitch -= 0.03
+					player.Turn(0, -0.03)
 				case 'w':
-					forwardDir := gs.player.Forward()
+					forwardDir := player.Forward()
 					thrustVector := forwardDir.Scale(thrust)
-					gs.player.Velocity = gs.player.Velocity.Add(thrustVector)
+					player.Velocity = player.Velocity.Add(thrustVector)
 				case 's':
-					brakeVector := gs.player.Velocity.Scale(-brakeForce)
-					gs.player.Velocity = gs.player.Velocity.Add(brakeVector)
-				}
-
-				// Clamp pitch to avoid gimbal lock
-				const maxPitch = math.Pi/2 - 0.01
-				if gs.player.Pitch > maxPitch {
-					gs.player.Pitch = maxPitch
-				} else if gs.player.Pitch < -maxPitch {
-					gs.player.Pitch = -maxPitch
+					brakeVector := player.Velocity.Scale(-brakeForce)
+					player.Velocity = player.Velocity.Add(brakeVector)
+				case ' ':
+					gs.world.SpawnBullet(bulletSpeed)
 				}
 
 			case *tcell.EventMouse:
 				newX, newY := ev.Position()
-
-				if gs.player.firstMouse {
-					gs.player.prevMouseX = newX
-					gs.player.prevMouseY = newY
-					gs.player.firstMouse = false
-				} else {
-					dx := float64(newX - gs.player.prevMouseX)
-					dy := float64(newY - gs.player.prevMouseY)
-
-					gs.player.Yaw -= dx * 0.05
-					gs.player.Pitch -= dy * 0.05
-
-					gs.player.prevMouseX = newX
-					gs.player.prevMouseY = newY
-				}
+				player.HandleMouseMove(newX, newY)
 			}
 		}
 	}()
 
+	// Drive the simulation on a fixed 60Hz accumulator so bullet motion and
+	// player physics stay frame-rate independent, while rendering as fast
+	// as the terminal allows.
+	var accumulator float64
+	previous := time.Now()
+
 	for {
 		select {
 		case <-quit:
 			return
 		default:
-			gs.Update()
-			screen.Clear()
+			now := time.Now()
+			frameTime := now.Sub(previous).Seconds()
+			previous = now
+			if frameTime > 0.25 {
+				frameTime = 0.25 // avoid a spiral of death after a big hitch
+			}
+			accumulator += frameTime
 
-			modelMatrix := vector.NewRotationY(gs.angle).Multiply(vector.NewRotationX(gs.angle))
+			for accumulator >= sim.FixedDT {
+				gs.Update()
+				accumulator -= sim.FixedDT
+			}
+
+			screen.Clear()
 
-			playerRotMatrix := vector.NewRotationX(-gs.player.Pitch).Multiply(vector.NewRotationY(-gs.player.Yaw))
-			playerTransMatrix := vector.NewTranslation(-gs.player.Position.X, -gs.player.Position.Y, -gs.player.Position.Z)
-			viewMatrix := playerRotMatrix.Multiply(playerTransMatrix)
+			viewMatrix := vector.NewLookAt(player.Position, player.Position.Add(player.Forward()), vector.Vec3{Y: 1})
 
 			width, height := screen.Size()
 			aspectRatio := float64(width) / float64(height) * 0.5
 			projectionMatrix := vector.NewPerspective(fov, aspectRatio, 0.1, 100.0)
-			mvpMatrix := projectionMatrix.Multiply(viewMatrix.Multiply(modelMatrix))
-			modelViewMatrix := viewMatrix.Multiply(modelMatrix)
-
-			for _, triangle := range triangles {
-				v1 := gs.vertices[triangle[0]]
-				v2 := gs.vertices[triangle[1]]
-				v3 := gs.vertices[triangle[2]]
-
-				// Transform vertices by the model-view matrix
-				tv1, _ := modelViewMatrix.MultiplyVec3(v1)
-				tv2, _ := modelViewMatrix.MultiplyVec3(v2)
-				tv3, _ := modelViewMatrix.MultiplyVec3(v3)
-
-				// Back-face culling
-				normal := (tv2.Sub(tv1)).Cross(tv3.Sub(tv1))
-				if normal.Dot(tv1) >= 0 {
-					continue
-				}
+			mvpMatrix := projectionMatrix.Multiply(viewMatrix)
 
-				// Project vertices
-				pv1, w1 := mvpMatrix.MultiplyVec3(v1)
-				pv2, w2 := mvpMatrix.MultiplyVec3(v2)
-				pv3, w3 := mvpMatrix.MultiplyVec3(v3)
+			depthBuf := render.NewDepthBuffer(width, height)
+			rasterizer := render.NewRasterizer(screen, depthBuf, sunLight)
 
-				// Clipping
-				if w1 < 0.1 || w2 < 0.1 || w3 < 0.1 {
-					continue
+			for _, mesh := range gs.world.StaticMeshes {
+				for i, triangle := range mesh.Faces {
+					v1 := mesh.Vertices[triangle[0]]
+					v2 := mesh.Vertices[triangle[1]]
+					v3 := mesh.Vertices[triangle[2]]
+
+					// Mesh vertices are already in world space: static
+					// scenery doesn't move, since it's also the geometry
+					// bullets collide against (see sim.World.collides).
+
+					// Transform vertices by the view matrix
+					tv1, _ := viewMatrix.MultiplyVec3(v1)
+					tv2, _ := viewMatrix.MultiplyVec3(v2)
+					tv3, _ := viewMatrix.MultiplyVec3(v3)
+
+					// Back-face culling
+					normal := (tv2.Sub(tv1)).Cross(tv3.Sub(tv1))
+					if normal.Dot(tv1) >= 0 {
+						continue
+					}
+
+					// Project vertices into clip space and split any
+					// triangle crossing the near plane instead of just
+					// dropping it.
+					cv1, w1 := mvpMatrix.MultiplyVec3(v1)
+					cv2, w2 := mvpMatrix.MultiplyVec3(v2)
+					cv3, w3 := mvpMatrix.MultiplyVec3(v3)
+
+					clipped := vector.ClipTriangleNearPlane(
+						vector.Vec4{X: cv1.X, Y: cv1.Y, Z: cv1.Z, W: w1},
+						vector.Vec4{X: cv2.X, Y: cv2.Y, Z: cv2.Z, W: w2},
+						vector.Vec4{X: cv3.X, Y: cv3.Y, Z: cv3.Z, W: w3},
+						0.1,
+					)
+
+					// Shading uses the triangle's world-space normal. Near-
+					// plane clipping only adds coplanar edges, so every
+					// clipped sub-triangle shares it. Meshes that supply
+					// parsed vertex normals are shaded by their average
+					// instead of the face's geometric normal.
+					var worldNormal vector.Vec3
+					if n := mesh.NormalIndices[i]; n[0] != obj.NoFaceNormal {
+						worldNormal = mesh.Normals[n[0]].Add(mesh.Normals[n[1]]).Add(mesh.Normals[n[2]])
+					} else {
+						worldNormal = v2.Sub(v1).Cross(v3.Sub(v1))
+					}
+
+					for _, tri := range clipped {
+						// Perspective divide
+						x1, y1 := tri[0].X/tri[0].W, tri[0].Y/tri[0].W
+						x2, y2 := tri[1].X/tri[1].W, tri[1].Y/tri[1].W
+						x3, y3 := tri[2].X/tri[2].W, tri[2].Y/tri[2].W
+
+						// Convert to screen coordinates
+						sx1 := int((x1 + 1) / 2 * float64(width))
+						sy1 := int((1 - y1) / 2 * float64(height))
+						sx2 := int((x2 + 1) / 2 * float64(width))
+						sy2 := int((1 - y2) / 2 * float64(height))
+						sx3 := int((x3 + 1) / 2 * float64(width))
+						sy3 := int((1 - y3) / 2 * float64(height))
+
+						if *wire {
+							drawLine(screen, sx1, sy1, sx2, sy2, tcell.StyleDefault)
+							drawLine(screen, sx2, sy2, sx3, sy3, tcell.StyleDefault)
+							drawLine(screen, sx3, sy3, sx1, sy1, tcell.StyleDefault)
+							continue
+						}
+
+						rasterizer.FillTriangle(
+							render.ScreenVertex{X: float64(sx1), Y: float64(sy1), InvW: 1 / tri[0].W},
+							render.ScreenVertex{X: float64(sx2), Y: float64(sy2), InvW: 1 / tri[1].W},
+							render.ScreenVertex{X: float64(sx3), Y: float64(sy3), InvW: 1 / tri[2].W},
+							worldNormal, tcell.StyleDefault,
+						)
+					}
 				}
+			}
 
-				// Perspective divide
-				pv1.X /= w1
-				pv1.Y /= w1
-				pv2.X /= w2
-				pv2.Y /= w2
-				pv3.X /= w3
-				pv3.Y /= w3
-
-				// Convert to screen coordinates
-				sx1 := int((pv1.X + 1) / 2 * float64(width))
-				sy1 := int((1 - pv1.Y) / 2 * float64(height))
-				sx2 := int((pv2.X + 1) / 2 * float64(width))
-				sy2 := int((1 - pv2.Y) / 2 * float64(height))
-				sx3 := int((pv3.X + 1) / 2 * float64(width))
-				sy3 := int((1 - pv3.Y) / 2 * float64(height))
-
-				drawLine(screen, sx1, sy1, sx2, sy2, tcell.StyleDefault)
-				drawLine(screen, sx2, sy2, sx3, sy3, tcell.StyleDefault)
-				drawLine(screen, sx3, sy3, sx1, sy1, tcell.StyleDefault)
+			for _, bullet := range gs.world.Projectiles {
+				pv, w := mvpMatrix.MultiplyVec3(bullet.Position)
+				if w < 0.1 {
+					continue
+				}
+				sx := int((pv.X/w + 1) / 2 * float64(width))
+				sy := int((1 - pv.Y/w) / 2 * float64(height))
+				if !depthBuf.TestAndSet(sx, sy, 1/w) {
+					continue
+				}
+				screen.SetContent(sx, sy, '*', nil, tcell.StyleDefault)
 			}
 
 			screen.Show()
-			time.Sleep(time.Millisecond * 16)
 		}
 	}
 }
@@ -0,0 +1,46 @@
+package obj
+
+import "spaceship/vector"
+
+// AABB is an axis-aligned bounding box.
+type AABB struct {
+	Min, Max vector.Vec3
+}
+
+// Contains reports whether p lies within the box, inclusive of its faces.
+func (b AABB) Contains(p vector.Vec3) bool {
+	return p.X >= b.Min.X && p.X <= b.Max.X &&
+		p.Y >= b.Min.Y && p.Y <= b.Max.Y &&
+		p.Z >= b.Min.Z && p.Z <= b.Max.Z
+}
+
+// Bounds computes the mesh's axis-aligned bounding box in its local
+// (object-space) coordinates.
+func (m Mesh) Bounds() AABB {
+	if len(m.Vertices) == 0 {
+		return AABB{}
+	}
+
+	min, max := m.Vertices[0], m.Vertices[0]
+	for _, v := range m.Vertices[1:] {
+		if v.X < min.X {
+			min.X = v.X
+		}
+		if v.Y < min.Y {
+			min.Y = v.Y
+		}
+		if v.Z < min.Z {
+			min.Z = v.Z
+		}
+		if v.X > max.X {
+			max.X = v.X
+		}
+		if v.Y > max.Y {
+			max.Y = v.Y
+		}
+		if v.Z > max.Z {
+			max.Z = v.Z
+		}
+	}
+	return AABB{Min: min, Max: max}
+}
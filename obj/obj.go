@@ -0,0 +1,194 @@
+// Package obj loads 3D models from the Wavefront OBJ format.
+package obj
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"spaceship/vector"
+)
+
+// Mesh is a triangulated 3D model: a flat list of vertex/normal positions
+// plus faces that index into them.
+type Mesh struct {
+	Vertices []vector.Vec3
+	Normals  []vector.Vec3
+	Faces    [][3]int
+
+	// NormalIndices holds, for each entry in Faces, the indices into Normals
+	// of that triangle's three vertex normals, or NoFaceNormal if that face's
+	// record had no "vn" indices (e.g. it only had "v" or "v/vt"
+	// components). It's always the same length as Faces, since an OBJ file
+	// can mix faces that carry vn data with faces that don't.
+	NormalIndices [][3]int
+}
+
+// NoFaceNormal is the NormalIndices sentinel for a face with no parsed vn
+// data.
+const NoFaceNormal = -1
+
+// Load reads and parses the Wavefront OBJ file at path.
+func Load(path string) (Mesh, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Mesh{}, fmt.Errorf("obj: %w", err)
+	}
+	defer f.Close()
+
+	mesh, err := Parse(f)
+	if err != nil {
+		return Mesh{}, fmt.Errorf("obj: %s: %w", path, err)
+	}
+	return mesh, nil
+}
+
+// Parse reads a Wavefront OBJ stream, recognizing "v", "vn", and "f"
+// records. Comments and blank lines are skipped. Faces with more than 3
+// vertices are triangulated as a fan. Vertex indices in face records are
+// 1-based and may be negative, in which case they count backwards from the
+// last vertex parsed so far.
+func Parse(r io.Reader) (Mesh, error) {
+	var mesh Mesh
+
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		switch fields[0] {
+		case "v":
+			v, err := parseVec3(fields[1:])
+			if err != nil {
+				return Mesh{}, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			mesh.Vertices = append(mesh.Vertices, v)
+
+		case "vn":
+			vn, err := parseVec3(fields[1:])
+			if err != nil {
+				return Mesh{}, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			mesh.Normals = append(mesh.Normals, vn)
+
+		case "f":
+			face := fields[1:]
+			if len(face) < 3 {
+				return Mesh{}, fmt.Errorf("line %d: face has fewer than 3 vertices", lineNo)
+			}
+
+			vIndices := make([]int, len(face))
+			nIndices := make([]int, len(face))
+			hasNormals := true
+			for i, v := range face {
+				parts := strings.SplitN(v, "/", 3)
+
+				idx, err := parseFaceIndex(parts[0], len(mesh.Vertices))
+				if err != nil {
+					return Mesh{}, fmt.Errorf("line %d: %w", lineNo, err)
+				}
+				vIndices[i] = idx
+
+				if len(parts) < 3 || parts[2] == "" {
+					hasNormals = false
+					continue
+				}
+				nIdx, err := parseFaceIndex(parts[2], len(mesh.Normals))
+				if err != nil {
+					return Mesh{}, fmt.Errorf("line %d: %w", lineNo, err)
+				}
+				nIndices[i] = nIdx
+			}
+
+			// Triangulate an n-gon as a fan around its first vertex.
+			for i := 1; i < len(vIndices)-1; i++ {
+				mesh.Faces = append(mesh.Faces, [3]int{vIndices[0], vIndices[i], vIndices[i+1]})
+
+				faceNormal := [3]int{NoFaceNormal, NoFaceNormal, NoFaceNormal}
+				if hasNormals {
+					faceNormal = [3]int{nIndices[0], nIndices[i], nIndices[i+1]}
+				}
+				mesh.NormalIndices = append(mesh.NormalIndices, faceNormal)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Mesh{}, fmt.Errorf("read: %w", err)
+	}
+
+	return mesh, nil
+}
+
+func parseVec3(fields []string) (vector.Vec3, error) {
+	if len(fields) < 3 {
+		return vector.Vec3{}, fmt.Errorf("expected 3 components, got %d", len(fields))
+	}
+
+	comps := make([]float64, 3)
+	for i := 0; i < 3; i++ {
+		c, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return vector.Vec3{}, fmt.Errorf("invalid component %q: %w", fields[i], err)
+		}
+		comps[i] = c
+	}
+	return vector.Vec3{X: comps[0], Y: comps[1], Z: comps[2]}, nil
+}
+
+// parseFaceIndex resolves a single index component of a face record (e.g.
+// the "v" or "vn" in "v/vt/vn"), converting OBJ's 1-based (and possibly
+// negative) indexing into a 0-based index into the elements parsed so far.
+// count is the number of elements (vertices or normals) parsed so far.
+func parseFaceIndex(component string, count int) (int, error) {
+	v, err := strconv.Atoi(component)
+	if err != nil {
+		return 0, fmt.Errorf("invalid face index %q: %w", component, err)
+	}
+
+	switch {
+	case v > 0:
+		v--
+	case v < 0:
+		v = count + v
+	default:
+		return 0, fmt.Errorf("face index %q must not be 0", component)
+	}
+
+	if v < 0 || v >= count {
+		return 0, fmt.Errorf("face index %q out of range (have %d elements)", component, count)
+	}
+	return v, nil
+}
+
+// DefaultCube returns the small unit cube used when no model is supplied on
+// the command line.
+func DefaultCube() Mesh {
+	return Mesh{
+		Vertices: []vector.Vec3{
+			{X: -1, Y: -1, Z: -1}, {X: 1, Y: -1, Z: -1}, {X: 1, Y: 1, Z: -1}, {X: -1, Y: 1, Z: -1},
+			{X: -1, Y: -1, Z: 1}, {X: 1, Y: -1, Z: 1}, {X: 1, Y: 1, Z: 1}, {X: -1, Y: 1, Z: 1},
+		},
+		Faces: [][3]int{
+			{0, 2, 1}, {0, 3, 2}, // Front face
+			{1, 2, 6}, {1, 6, 5}, // Right face
+			{0, 1, 5}, {0, 5, 4}, // Top face
+			{3, 7, 6}, {3, 6, 2}, // Bottom face
+			{0, 4, 7}, {0, 7, 3}, // Left face
+			{4, 5, 6}, {4, 6, 7}, // Back face
+		},
+		NormalIndices: [][3]int{
+			{NoFaceNormal, NoFaceNormal, NoFaceNormal}, {NoFaceNormal, NoFaceNormal, NoFaceNormal},
+			{NoFaceNormal, NoFaceNormal, NoFaceNormal}, {NoFaceNormal, NoFaceNormal, NoFaceNormal},
+			{NoFaceNormal, NoFaceNormal, NoFaceNormal}, {NoFaceNormal, NoFaceNormal, NoFaceNormal},
+			{NoFaceNormal, NoFaceNormal, NoFaceNormal}, {NoFaceNormal, NoFaceNormal, NoFaceNormal},
+			{NoFaceNormal, NoFaceNormal, NoFaceNormal}, {NoFaceNormal, NoFaceNormal, NoFaceNormal},
+			{NoFaceNormal, NoFaceNormal, NoFaceNormal}, {NoFaceNormal, NoFaceNormal, NoFaceNormal},
+		},
+	}
+}
@@ -0,0 +1,182 @@
+package obj
+
+import (
+	"strings"
+	"testing"
+
+	"spaceship/vector"
+)
+
+func TestParseTriangle(t *testing.T) {
+	src := `# a single triangle
+v 0 0 0
+v 1 0 0
+v 0 1 0
+vn 0 0 1
+f 1 2 3
+`
+	mesh, err := Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(mesh.Vertices) != 3 {
+		t.Fatalf("got %d vertices, want 3", len(mesh.Vertices))
+	}
+	if len(mesh.Normals) != 1 {
+		t.Fatalf("got %d normals, want 1", len(mesh.Normals))
+	}
+	want := [3]int{0, 1, 2}
+	if len(mesh.Faces) != 1 || mesh.Faces[0] != want {
+		t.Fatalf("got faces %v, want [%v]", mesh.Faces, want)
+	}
+}
+
+func TestParseFanTriangulatesQuad(t *testing.T) {
+	src := `v 0 0 0
+v 1 0 0
+v 1 1 0
+v 0 1 0
+f 1 2 3 4
+`
+	mesh, err := Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	want := [][3]int{{0, 1, 2}, {0, 2, 3}}
+	if len(mesh.Faces) != len(want) {
+		t.Fatalf("got %d faces, want %d", len(mesh.Faces), len(want))
+	}
+	for i, f := range want {
+		if mesh.Faces[i] != f {
+			t.Errorf("face %d: got %v, want %v", i, mesh.Faces[i], f)
+		}
+	}
+}
+
+func TestParseNegativeIndices(t *testing.T) {
+	src := `v 0 0 0
+v 1 0 0
+v 0 1 0
+f -3 -2 -1
+`
+	mesh, err := Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	want := [3]int{0, 1, 2}
+	if len(mesh.Faces) != 1 || mesh.Faces[0] != want {
+		t.Fatalf("got faces %v, want [%v]", mesh.Faces, want)
+	}
+}
+
+func TestParseVertexTextureNormalTriple(t *testing.T) {
+	src := `v 0 0 0
+v 1 0 0
+v 0 1 0
+vn 0 0 1
+f 1/1/1 2/2/1 3/3/1
+`
+	mesh, err := Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	want := [3]int{0, 1, 2}
+	if len(mesh.Faces) != 1 || mesh.Faces[0] != want {
+		t.Fatalf("got faces %v, want [%v]", mesh.Faces, want)
+	}
+	wantNormals := [3]int{0, 0, 0} // all three vertices reference the same vn
+	if len(mesh.NormalIndices) != 1 || mesh.NormalIndices[0] != wantNormals {
+		t.Fatalf("got normal indices %v, want [%v]", mesh.NormalIndices, wantNormals)
+	}
+}
+
+func TestParseMixedFacesKeepsNormalIndicesAligned(t *testing.T) {
+	src := `v 0 0 0
+v 1 0 0
+v 0 1 0
+v 1 1 0
+vn 0 0 1
+f 1 2 3
+f 2/2/1 3/3/1 4/4/1
+`
+	mesh, err := Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(mesh.NormalIndices) != len(mesh.Faces) {
+		t.Fatalf("got %d normal indices, want %d (one per face, aligned by index)", len(mesh.NormalIndices), len(mesh.Faces))
+	}
+	if got, want := mesh.NormalIndices[0], [3]int{NoFaceNormal, NoFaceNormal, NoFaceNormal}; got != want {
+		t.Errorf("face 0 (no vn): got normal indices %v, want %v", got, want)
+	}
+	if got, want := mesh.NormalIndices[1], [3]int{0, 0, 0}; got != want {
+		t.Errorf("face 1 (has vn): got normal indices %v, want %v", got, want)
+	}
+}
+
+func TestParseSkipsCommentsAndBlankLines(t *testing.T) {
+	src := "\n# comment\n\nv 0 0 0\nv 1 0 0\nv 0 1 0\n\n# another\nf 1 2 3\n"
+	mesh, err := Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(mesh.Vertices) != 3 || len(mesh.Faces) != 1 {
+		t.Fatalf("got %d vertices and %d faces", len(mesh.Vertices), len(mesh.Faces))
+	}
+}
+
+func TestParseMalformedVertex(t *testing.T) {
+	src := "v 0 0 notanumber\n"
+	if _, err := Parse(strings.NewReader(src)); err == nil {
+		t.Fatal("expected error for malformed vertex line")
+	}
+}
+
+func TestParseFaceIndexOutOfRange(t *testing.T) {
+	src := "v 0 0 0\nv 1 0 0\nv 0 1 0\nf 1 2 4\n"
+	if _, err := Parse(strings.NewReader(src)); err == nil {
+		t.Fatal("expected error for out-of-range face index")
+	}
+}
+
+func TestParseFaceTooFewVertices(t *testing.T) {
+	src := "v 0 0 0\nv 1 0 0\nf 1 2\n"
+	if _, err := Parse(strings.NewReader(src)); err == nil {
+		t.Fatal("expected error for face with fewer than 3 vertices")
+	}
+}
+
+func TestDefaultCube(t *testing.T) {
+	cube := DefaultCube()
+	if len(cube.Vertices) != 8 {
+		t.Fatalf("got %d vertices, want 8", len(cube.Vertices))
+	}
+	if len(cube.Faces) != 12 {
+		t.Fatalf("got %d faces, want 12", len(cube.Faces))
+	}
+	if len(cube.NormalIndices) != len(cube.Faces) {
+		t.Fatalf("got %d normal indices, want %d (one per face, per the Mesh.NormalIndices invariant)", len(cube.NormalIndices), len(cube.Faces))
+	}
+}
+
+func TestBounds(t *testing.T) {
+	cube := DefaultCube()
+	b := cube.Bounds()
+	want := AABB{Min: vector.Vec3{X: -1, Y: -1, Z: -1}, Max: vector.Vec3{X: 1, Y: 1, Z: 1}}
+	if b != want {
+		t.Fatalf("got bounds %v, want %v", b, want)
+	}
+}
+
+func TestAABBContains(t *testing.T) {
+	b := AABB{Min: vector.Vec3{X: -1, Y: -1, Z: -1}, Max: vector.Vec3{X: 1, Y: 1, Z: 1}}
+	if !b.Contains(vector.Vec3{X: 0, Y: 0, Z: 0}) {
+		t.Error("center point should be contained")
+	}
+	if !b.Contains(vector.Vec3{X: 1, Y: 1, Z: 1}) {
+		t.Error("a point on the boundary should be contained")
+	}
+	if b.Contains(vector.Vec3{X: 2, Y: 0, Z: 0}) {
+		t.Error("a point outside the box should not be contained")
+	}
+}
@@ -0,0 +1,165 @@
+// Package render rasterizes filled, depth-tested, flat-shaded triangles
+// onto a tcell.Screen.
+package render
+
+import (
+	"math"
+
+	"github.com/gdamore/tcell/v2"
+	"spaceship/vector"
+)
+
+// Ramp is the ASCII shading ramp used to map light intensity to a glyph,
+// from darkest to brightest.
+const Ramp = " .:-=+*#%@"
+
+// Light is a single directional light used for flat shading: Direction
+// points from the light toward the scene.
+type Light struct {
+	Direction vector.Vec3
+	Intensity float64
+}
+
+// DepthBuffer holds one "nearness" value (interpolated 1/w) per screen
+// cell, used to resolve overlapping triangles during rasterization.
+type DepthBuffer struct {
+	width, height int
+	depth         []float64
+}
+
+// NewDepthBuffer allocates a depth buffer sized to width x height cells.
+func NewDepthBuffer(width, height int) *DepthBuffer {
+	return &DepthBuffer{
+		width:  width,
+		height: height,
+		depth:  make([]float64, width*height),
+	}
+}
+
+// Clear resets every cell so the next frame's triangles draw over it.
+func (d *DepthBuffer) Clear() {
+	for i := range d.depth {
+		d.depth[i] = 0
+	}
+}
+
+// TestAndSet reports whether invW (the interpolated 1/w at x,y) is nearer
+// to the camera than whatever is currently stored at that cell, and if so
+// records it. Cells outside the buffer always fail the test.
+func (d *DepthBuffer) TestAndSet(x, y int, invW float64) bool {
+	if x < 0 || x >= d.width || y < 0 || y >= d.height {
+		return false
+	}
+	i := y*d.width + x
+	if invW <= d.depth[i] {
+		return false
+	}
+	d.depth[i] = invW
+	return true
+}
+
+// ScreenVertex is a rasterizer input vertex: its screen-space position and
+// its interpolated 1/w, used for perspective-correct depth testing.
+type ScreenVertex struct {
+	X, Y float64
+	InvW float64
+}
+
+// Rasterizer fills triangles into a tcell.Screen, testing each covered
+// pixel against a shared DepthBuffer and shading it under a single Light.
+type Rasterizer struct {
+	Screen tcell.Screen
+	Depth  *DepthBuffer
+	Light  Light
+}
+
+// NewRasterizer constructs a Rasterizer drawing into screen, depth-tested
+// against depth and shaded under light.
+func NewRasterizer(screen tcell.Screen, depth *DepthBuffer, light Light) *Rasterizer {
+	return &Rasterizer{Screen: screen, Depth: depth, Light: light}
+}
+
+// edge evaluates the 2D edge function for point (px,py) against the
+// directed edge (ax,ay)->(bx,by). Its sign indicates which side of the
+// edge the point falls on.
+func edge(ax, ay, bx, by, px, py float64) float64 {
+	return (bx-ax)*(py-ay) - (by-ay)*(px-ax)
+}
+
+// FillTriangle rasterizes a single triangle over its screen-space bounding
+// box using edge-function/barycentric weights, depth-tests each covered
+// pixel, and shades it from normal (the triangle's flat world-space
+// normal) dotted against the rasterizer's light.
+func (r *Rasterizer) FillTriangle(v1, v2, v3 ScreenVertex, normal vector.Vec3, style tcell.Style) {
+	width, height := r.Screen.Size()
+
+	minX := int(math.Floor(math.Min(v1.X, math.Min(v2.X, v3.X))))
+	maxX := int(math.Ceil(math.Max(v1.X, math.Max(v2.X, v3.X))))
+	minY := int(math.Floor(math.Min(v1.Y, math.Min(v2.Y, v3.Y))))
+	maxY := int(math.Ceil(math.Max(v1.Y, math.Max(v2.Y, v3.Y))))
+
+	if maxX < 0 || maxY < 0 || minX >= width || minY >= height {
+		return
+	}
+	if minX < 0 {
+		minX = 0
+	}
+	if minY < 0 {
+		minY = 0
+	}
+	if maxX >= width {
+		maxX = width - 1
+	}
+	if maxY >= height {
+		maxY = height - 1
+	}
+
+	area := edge(v1.X, v1.Y, v2.X, v2.Y, v3.X, v3.Y)
+	if area == 0 {
+		return // degenerate (zero-area) triangle
+	}
+
+	glyph := r.shade(normal)
+
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			px := float64(x) + 0.5
+			py := float64(y) + 0.5
+
+			w0 := edge(v2.X, v2.Y, v3.X, v3.Y, px, py)
+			w1 := edge(v3.X, v3.Y, v1.X, v1.Y, px, py)
+			w2 := edge(v1.X, v1.Y, v2.X, v2.Y, px, py)
+
+			if area < 0 {
+				if w0 > 0 || w1 > 0 || w2 > 0 {
+					continue
+				}
+			} else if w0 < 0 || w1 < 0 || w2 < 0 {
+				continue
+			}
+
+			b0 := w0 / area
+			b1 := w1 / area
+			b2 := w2 / area
+			invW := b0*v1.InvW + b1*v2.InvW + b2*v3.InvW
+
+			if !r.Depth.TestAndSet(x, y, invW) {
+				continue
+			}
+			r.Screen.SetContent(x, y, glyph, nil, style)
+		}
+	}
+}
+
+// shade maps normal dotted with the light direction onto a glyph from Ramp.
+func (r *Rasterizer) shade(normal vector.Vec3) rune {
+	n := normal.Normalize()
+	intensity := -n.Dot(r.Light.Direction.Normalize()) * r.Light.Intensity
+	if intensity < 0 {
+		intensity = 0
+	} else if intensity > 1 {
+		intensity = 1
+	}
+	idx := int(intensity * float64(len(Ramp)-1))
+	return rune(Ramp[idx])
+}
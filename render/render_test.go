@@ -0,0 +1,159 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+	"spaceship/vector"
+)
+
+func newTestScreen(t *testing.T, width, height int) tcell.SimulationScreen {
+	t.Helper()
+	screen := tcell.NewSimulationScreen("")
+	if err := screen.Init(); err != nil {
+		t.Fatalf("screen.Init: %v", err)
+	}
+	screen.SetSize(width, height)
+	return screen
+}
+
+func TestDepthBufferNearerWins(t *testing.T) {
+	d := NewDepthBuffer(4, 4)
+
+	if !d.TestAndSet(1, 1, 0.5) {
+		t.Fatal("first write to an empty cell should succeed")
+	}
+	if d.TestAndSet(1, 1, 0.2) {
+		t.Fatal("farther sample (smaller 1/w) should not overwrite a nearer one")
+	}
+	if !d.TestAndSet(1, 1, 0.9) {
+		t.Fatal("nearer sample (larger 1/w) should overwrite")
+	}
+}
+
+func TestDepthBufferOutOfBounds(t *testing.T) {
+	d := NewDepthBuffer(4, 4)
+	for _, p := range [][2]int{{-1, 0}, {0, -1}, {4, 0}, {0, 4}} {
+		if d.TestAndSet(p[0], p[1], 1) {
+			t.Errorf("TestAndSet(%d, %d) should fail out of bounds", p[0], p[1])
+		}
+	}
+}
+
+func TestDepthBufferClear(t *testing.T) {
+	d := NewDepthBuffer(2, 2)
+	d.TestAndSet(0, 0, 1.0)
+	d.Clear()
+	if !d.TestAndSet(0, 0, 0.1) {
+		t.Fatal("clear should reset stored depth so a far sample can write again")
+	}
+}
+
+func TestFillTriangleWritesDepthTestedGlyph(t *testing.T) {
+	screen := newTestScreen(t, 10, 10)
+	depth := NewDepthBuffer(10, 10)
+	light := Light{Direction: vector.Vec3{X: 0, Y: 0, Z: 1}, Intensity: 1}
+	r := NewRasterizer(screen, depth, light)
+
+	v1 := ScreenVertex{X: 1, Y: 1, InvW: 1}
+	v2 := ScreenVertex{X: 8, Y: 1, InvW: 1}
+	v3 := ScreenVertex{X: 4, Y: 8, InvW: 1}
+	normal := vector.Vec3{X: 0, Y: 0, Z: -1}
+
+	r.FillTriangle(v1, v2, v3, normal, tcell.StyleDefault)
+
+	mainc, _, _, _ := screen.GetContent(4, 4)
+	if mainc == ' ' || mainc == 0 {
+		t.Fatalf("expected a shaded glyph inside the triangle, got %q", mainc)
+	}
+}
+
+func TestFillTriangleRespectsDepthBuffer(t *testing.T) {
+	screen := newTestScreen(t, 10, 10)
+	depth := NewDepthBuffer(10, 10)
+	light := Light{Direction: vector.Vec3{X: 0, Y: 0, Z: 1}, Intensity: 1}
+	r := NewRasterizer(screen, depth, light)
+
+	near := vector.Vec3{X: 0, Y: 0, Z: -1}
+	far := vector.Vec3{X: 0, Y: 0, Z: 1}
+
+	// Draw the far triangle (low InvW) first, then a nearer one (high InvW)
+	// covering the same pixel: the nearer glyph should win.
+	r.FillTriangle(
+		ScreenVertex{X: 1, Y: 1, InvW: 0.1},
+		ScreenVertex{X: 8, Y: 1, InvW: 0.1},
+		ScreenVertex{X: 4, Y: 8, InvW: 0.1},
+		far, tcell.StyleDefault,
+	)
+	r.FillTriangle(
+		ScreenVertex{X: 1, Y: 1, InvW: 0.9},
+		ScreenVertex{X: 8, Y: 1, InvW: 0.9},
+		ScreenVertex{X: 4, Y: 8, InvW: 0.9},
+		near, tcell.StyleDefault,
+	)
+
+	got, _, _, _ := screen.GetContent(4, 4)
+	want := rune(Ramp[len(Ramp)-1])
+	if got != want {
+		t.Fatalf("nearer triangle should win the depth test: got %q, want %q", got, want)
+	}
+
+	// Now draw the far triangle again: it must not overwrite the nearer one.
+	r.FillTriangle(
+		ScreenVertex{X: 1, Y: 1, InvW: 0.1},
+		ScreenVertex{X: 8, Y: 1, InvW: 0.1},
+		ScreenVertex{X: 4, Y: 8, InvW: 0.1},
+		far, tcell.StyleDefault,
+	)
+	got, _, _, _ = screen.GetContent(4, 4)
+	if got != want {
+		t.Fatalf("farther triangle drawn later should not overwrite nearer: got %q, want %q", got, want)
+	}
+}
+
+func TestFillTriangleDegenerateDoesNotPanic(t *testing.T) {
+	screen := newTestScreen(t, 10, 10)
+	depth := NewDepthBuffer(10, 10)
+	r := NewRasterizer(screen, depth, Light{Direction: vector.Vec3{Z: 1}, Intensity: 1})
+
+	// All three vertices collinear: zero area.
+	r.FillTriangle(
+		ScreenVertex{X: 1, Y: 1, InvW: 1},
+		ScreenVertex{X: 5, Y: 1, InvW: 1},
+		ScreenVertex{X: 9, Y: 1, InvW: 1},
+		vector.Vec3{Z: -1}, tcell.StyleDefault,
+	)
+}
+
+func TestFillTriangleOffScreenBoundingBoxDoesNotPanic(t *testing.T) {
+	screen := newTestScreen(t, 10, 10)
+	depth := NewDepthBuffer(10, 10)
+	r := NewRasterizer(screen, depth, Light{Direction: vector.Vec3{Z: 1}, Intensity: 1})
+
+	cases := [][3]ScreenVertex{
+		{{X: -30, Y: -30, InvW: 1}, {X: -20, Y: -30, InvW: 1}, {X: -25, Y: -20, InvW: 1}},
+		{{X: 30, Y: 30, InvW: 1}, {X: 40, Y: 30, InvW: 1}, {X: 35, Y: 40, InvW: 1}},
+	}
+	for _, c := range cases {
+		r.FillTriangle(c[0], c[1], c[2], vector.Vec3{Z: -1}, tcell.StyleDefault)
+	}
+}
+
+func TestFillTriangleClampsBoundingBoxToScreen(t *testing.T) {
+	screen := newTestScreen(t, 10, 10)
+	depth := NewDepthBuffer(10, 10)
+	r := NewRasterizer(screen, depth, Light{Direction: vector.Vec3{Z: 1}, Intensity: 1})
+
+	// Triangle extends well past every edge of the screen.
+	r.FillTriangle(
+		ScreenVertex{X: -20, Y: -20, InvW: 1},
+		ScreenVertex{X: 30, Y: -20, InvW: 1},
+		ScreenVertex{X: 5, Y: 30, InvW: 1},
+		vector.Vec3{Z: -1}, tcell.StyleDefault,
+	)
+
+	mainc, _, _, _ := screen.GetContent(5, 5)
+	if mainc == ' ' || mainc == 0 {
+		t.Fatalf("expected the clamped triangle to still cover the screen center, got %q", mainc)
+	}
+}
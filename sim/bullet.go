@@ -0,0 +1,31 @@
+package sim
+
+import "spaceship/vector"
+
+// Bullet is a projectile fired by the player: it travels in a straight line
+// and is removed once it has been alive for its TTL or the World reports a
+// collision against it.
+type Bullet struct {
+	Position vector.Vec3
+	Velocity vector.Vec3
+
+	ttl float64
+}
+
+// NewBullet creates a bullet at position traveling at velocity, expiring
+// after ttl seconds if nothing collides with it first.
+func NewBullet(position, velocity vector.Vec3, ttl float64) *Bullet {
+	return &Bullet{Position: position, Velocity: velocity, ttl: ttl}
+}
+
+// Update integrates the bullet's position by one fixed timestep and ticks
+// down its remaining lifetime.
+func (b *Bullet) Update(dt float64) {
+	b.Position = b.Position.Add(b.Velocity.Scale(dt))
+	b.ttl -= dt
+}
+
+// Expired reports whether the bullet has outlived its TTL.
+func (b *Bullet) Expired() bool {
+	return b.ttl <= 0
+}
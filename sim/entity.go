@@ -0,0 +1,13 @@
+package sim
+
+// Entity is anything a World advances once per fixed timestep and that may
+// eventually expire and be dropped.
+type Entity interface {
+	Update(dt float64)
+	Expired() bool
+}
+
+var (
+	_ Entity = (*Player)(nil)
+	_ Entity = (*Bullet)(nil)
+)
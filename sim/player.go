@@ -0,0 +1,72 @@
+package sim
+
+import (
+	"spaceship/vector"
+)
+
+// friction is the per-tick velocity decay applied while the player coasts.
+const friction = 0.99
+
+// Player is the camera/ship the user flies around the scene.
+type Player struct {
+	Position    vector.Vec3
+	Velocity    vector.Vec3
+	Orientation vector.Quaternion
+
+	prevMouseX int
+	prevMouseY int
+	firstMouse bool
+}
+
+// NewPlayer creates a player at position, facing -Z with zero velocity.
+func NewPlayer(position vector.Vec3) *Player {
+	return &Player{Position: position, Orientation: vector.NewIdentityQuaternion(), firstMouse: true}
+}
+
+// Forward returns the direction the player is currently facing.
+func (p *Player) Forward() vector.Vec3 {
+	dir, _ := p.Orientation.ToMat4().MultiplyVec3(vector.Vec3{Z: -1})
+	return dir
+}
+
+// Turn rotates the player by deltaYaw around the world's up axis and
+// deltaPitch around the player's own local right axis. Composing rotations
+// this way, rather than accumulating Euler angles, means there's no gimbal
+// lock and no need to clamp pitch.
+func (p *Player) Turn(deltaYaw, deltaPitch float64) {
+	yaw := vector.FromAxisAngle(vector.Vec3{Y: 1}, deltaYaw)
+	pitch := vector.FromAxisAngle(vector.Vec3{X: 1}, deltaPitch)
+	p.Orientation = yaw.Mul(p.Orientation.Mul(pitch)).Normalize()
+}
+
+// HandleMouseMove turns the player by the delta between (x,y) and the
+// previously reported mouse position, ignoring the very first report
+// (there's no prior position to diff against yet).
+func (p *Player) HandleMouseMove(x, y int) {
+	if p.firstMouse {
+		p.prevMouseX = x
+		p.prevMouseY = y
+		p.firstMouse = false
+		return
+	}
+
+	dx := float64(x - p.prevMouseX)
+	dy := float64(y - p.prevMouseY)
+
+	p.Turn(-dx*0.05, -dy*0.05)
+
+	p.prevMouseX = x
+	p.prevMouseY = y
+}
+
+// Update integrates the player's velocity and position by one fixed
+// timestep.
+func (p *Player) Update(dt float64) {
+	p.Velocity = p.Velocity.Scale(friction)
+	p.Position = p.Position.Add(p.Velocity)
+}
+
+// Expired always reports false: the player is never removed from the World.
+func (p *Player) Expired() bool {
+	return false
+}
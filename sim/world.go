@@ -0,0 +1,73 @@
+// Package sim drives the game's fixed-timestep simulation: the player, the
+// static meshes making up the scene, and dynamic projectiles.
+package sim
+
+import (
+	"spaceship/obj"
+	"spaceship/vector"
+)
+
+// FixedDT is the simulation's fixed timestep: 60 ticks per second,
+// independent of however fast the terminal can render.
+const FixedDT = 1.0 / 60.0
+
+// bulletTTL is how long an unspent bullet stays alive before despawning.
+const bulletTTL = 5.0
+
+// World owns everything the simulation advances: the player, the static
+// meshes that make up the scene, and the player's in-flight projectiles.
+type World struct {
+	Player       *Player
+	StaticMeshes []obj.Mesh
+	Projectiles  []*Bullet
+
+	// staticBounds holds each StaticMeshes entry's AABB, computed once in
+	// NewWorld since static meshes never move, so collides doesn't re-scan
+	// every mesh's vertices on every tick.
+	staticBounds []obj.AABB
+}
+
+// NewWorld creates a World with player and the given static scenery.
+func NewWorld(player *Player, staticMeshes []obj.Mesh) *World {
+	bounds := make([]obj.AABB, len(staticMeshes))
+	for i, mesh := range staticMeshes {
+		bounds[i] = mesh.Bounds()
+	}
+	return &World{Player: player, StaticMeshes: staticMeshes, staticBounds: bounds}
+}
+
+// SpawnBullet fires a bullet from the player's position along their facing
+// direction at speed (in units/second), inheriting the player's own
+// velocity. Player.Velocity is a units/tick quantity (see Player.Update),
+// so it's converted to units/second before being added to speed.
+func (w *World) SpawnBullet(speed float64) {
+	playerVelocity := w.Player.Velocity.Scale(1 / FixedDT)
+	velocity := w.Player.Forward().Scale(speed).Add(playerVelocity)
+	w.Projectiles = append(w.Projectiles, NewBullet(w.Player.Position, velocity, bulletTTL))
+}
+
+// Step advances the player and every projectile by one fixed timestep,
+// then drops projectiles that have expired or hit a static mesh.
+func (w *World) Step(dt float64) {
+	w.Player.Update(dt)
+
+	live := w.Projectiles[:0]
+	for _, b := range w.Projectiles {
+		b.Update(dt)
+		if b.Expired() || w.collides(b.Position) {
+			continue
+		}
+		live = append(live, b)
+	}
+	w.Projectiles = live
+}
+
+// collides reports whether p falls within any static mesh's bounding box.
+func (w *World) collides(p vector.Vec3) bool {
+	for _, b := range w.staticBounds {
+		if b.Contains(p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package sim
+
+import (
+	"testing"
+
+	"spaceship/obj"
+	"spaceship/vector"
+)
+
+func TestSpawnBulletInheritsPlayerVelocity(t *testing.T) {
+	player := NewPlayer(vector.Vec3{X: 1, Y: 2, Z: 3})
+	player.Velocity = vector.Vec3{X: 0, Y: 0, Z: 1}
+	world := NewWorld(player, nil)
+
+	world.SpawnBullet(2)
+
+	if len(world.Projectiles) != 1 {
+		t.Fatalf("got %d projectiles, want 1", len(world.Projectiles))
+	}
+	b := world.Projectiles[0]
+	if b.Position != player.Position {
+		t.Errorf("bullet should spawn at the player's position: got %v, want %v", b.Position, player.Position)
+	}
+	want := player.Forward().Scale(2).Add(player.Velocity.Scale(1 / FixedDT))
+	if b.Velocity != want {
+		t.Errorf("got velocity %v, want %v", b.Velocity, want)
+	}
+}
+
+func TestStepExpiresBulletsByTTL(t *testing.T) {
+	world := NewWorld(NewPlayer(vector.Vec3{}), nil)
+	world.Projectiles = []*Bullet{NewBullet(vector.Vec3{}, vector.Vec3{}, 1)}
+
+	world.Step(0.5)
+	if len(world.Projectiles) != 1 {
+		t.Fatalf("bullet should survive before its TTL elapses, got %d projectiles", len(world.Projectiles))
+	}
+
+	world.Step(0.6)
+	if len(world.Projectiles) != 0 {
+		t.Fatalf("bullet should be removed once its TTL elapses, got %d projectiles", len(world.Projectiles))
+	}
+}
+
+func TestStepRemovesBulletsCollidingWithStaticMesh(t *testing.T) {
+	wall := obj.Mesh{Vertices: []vector.Vec3{{X: -1, Y: -1, Z: -1}, {X: 1, Y: 1, Z: 1}}}
+	world := NewWorld(NewPlayer(vector.Vec3{}), []obj.Mesh{wall})
+	world.Projectiles = []*Bullet{NewBullet(vector.Vec3{X: -5, Y: 0, Z: 0}, vector.Vec3{X: 10, Y: 0, Z: 0}, 5)}
+
+	world.Step(0.5) // moves the bullet to (0,0,0), inside the wall's bounds
+
+	if len(world.Projectiles) != 0 {
+		t.Fatalf("bullet should have been removed on collision, got %d projectiles", len(world.Projectiles))
+	}
+}
+
+func TestPlayerNeverExpires(t *testing.T) {
+	p := NewPlayer(vector.Vec3{})
+	if p.Expired() {
+		t.Error("a player should never report as expired")
+	}
+}
@@ -92,3 +92,91 @@ func NewPerspective(fov, aspectRatio, near, far float64) Mat4x4 {
 	}
 }
 
+// NewLookAt builds a view matrix that transforms world-space points (via
+// v.MultiplyVec3) into the space of a camera sitting at eye, looking
+// toward target, with up used to disambiguate roll.
+func NewLookAt(eye, target, up Vec3) Mat4x4 {
+	zAxis := eye.Sub(target).Normalize()
+	xAxis := up.Cross(zAxis).Normalize()
+	yAxis := zAxis.Cross(xAxis)
+
+	return Mat4x4{
+		M: [16]float64{
+			xAxis.X, yAxis.X, zAxis.X, 0,
+			xAxis.Y, yAxis.Y, zAxis.Y, 0,
+			xAxis.Z, yAxis.Z, zAxis.Z, 0,
+			-xAxis.Dot(eye), -yAxis.Dot(eye), -zAxis.Dot(eye), 1,
+		},
+	}
+}
+
+func (mat Mat4x4) at(row, col int) float64 {
+	return mat.M[row*4+col]
+}
+
+// minor3x3 computes the determinant of the 3x3 matrix left after deleting
+// skipRow and skipCol from mat.
+func (mat Mat4x4) minor3x3(skipRow, skipCol int) float64 {
+	var v [9]float64
+	i := 0
+	for r := 0; r < 4; r++ {
+		if r == skipRow {
+			continue
+		}
+		for c := 0; c < 4; c++ {
+			if c == skipCol {
+				continue
+			}
+			v[i] = mat.at(r, c)
+			i++
+		}
+	}
+	return v[0]*(v[4]*v[8]-v[5]*v[7]) -
+		v[1]*(v[3]*v[8]-v[5]*v[6]) +
+		v[2]*(v[3]*v[7]-v[4]*v[6])
+}
+
+// Determinant computes det(mat) by cofactor expansion along the first row.
+func (mat Mat4x4) Determinant() float64 {
+	var det float64
+	sign := 1.0
+	for c := 0; c < 4; c++ {
+		det += sign * mat.at(0, c) * mat.minor3x3(0, c)
+		sign = -sign
+	}
+	return det
+}
+
+// Transpose returns mat with rows and columns swapped.
+func (mat Mat4x4) Transpose() Mat4x4 {
+	var t Mat4x4
+	for r := 0; r < 4; r++ {
+		for c := 0; c < 4; c++ {
+			t.M[c*4+r] = mat.at(r, c)
+		}
+	}
+	return t
+}
+
+// Inverse returns mat's inverse via the adjugate (cofactor-transpose)
+// method, and false if mat is singular.
+func (mat Mat4x4) Inverse() (Mat4x4, bool) {
+	det := mat.Determinant()
+	if det == 0 {
+		return Mat4x4{}, false
+	}
+
+	var inv Mat4x4
+	for r := 0; r < 4; r++ {
+		for c := 0; c < 4; c++ {
+			sign := 1.0
+			if (r+c)%2 != 0 {
+				sign = -1
+			}
+			cofactor := sign * mat.minor3x3(r, c)
+			// The adjugate is the cofactor matrix transposed.
+			inv.M[c*4+r] = cofactor / det
+		}
+	}
+	return inv, true
+}
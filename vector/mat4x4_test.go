@@ -0,0 +1,89 @@
+package vector
+
+import (
+	"math"
+	"testing"
+)
+
+func approxEqualMat4x4(a, b Mat4x4, eps float64) bool {
+	for i := range a.M {
+		if math.Abs(a.M[i]-b.M[i]) > eps {
+			return false
+		}
+	}
+	return true
+}
+
+func identity() Mat4x4 {
+	return Mat4x4{M: [16]float64{
+		1, 0, 0, 0,
+		0, 1, 0, 0,
+		0, 0, 1, 0,
+		0, 0, 0, 1,
+	}}
+}
+
+func TestInverseOfRotationsAndTranslations(t *testing.T) {
+	angles := []float64{0, 0.3, 1.1, -0.7, math.Pi / 2, math.Pi}
+	translations := [][3]float64{{0, 0, 0}, {1, 2, 3}, {-5, 0.5, 10}}
+
+	for _, angle := range angles {
+		for _, tr := range translations {
+			m := NewRotationX(angle).
+				Multiply(NewRotationY(angle * 0.5)).
+				Multiply(NewRotationZ(angle * 0.25)).
+				Multiply(NewTranslation(tr[0], tr[1], tr[2]))
+
+			inv, ok := m.Inverse()
+			if !ok {
+				t.Fatalf("angle=%v tr=%v: expected an invertible matrix", angle, tr)
+			}
+			if got := m.Multiply(inv); !approxEqualMat4x4(got, identity(), 1e-9) {
+				t.Errorf("angle=%v tr=%v: m.Multiply(inv) = %v, want identity", angle, tr, got.M)
+			}
+		}
+	}
+}
+
+func TestInverseSingularMatrix(t *testing.T) {
+	singular := Mat4x4{} // the all-zero matrix has determinant 0
+	if _, ok := singular.Inverse(); ok {
+		t.Fatal("expected Inverse to report false for a singular matrix")
+	}
+}
+
+func TestDeterminantOfIdentity(t *testing.T) {
+	if got := identity().Determinant(); math.Abs(got-1) > 1e-12 {
+		t.Errorf("got determinant %v, want 1", got)
+	}
+}
+
+func TestTransposeTwiceIsIdentityOperation(t *testing.T) {
+	m := NewRotationY(0.6).Multiply(NewTranslation(1, 2, 3))
+	if got := m.Transpose().Transpose(); !approxEqualMat4x4(got, m, 1e-12) {
+		t.Errorf("transposing twice should return the original matrix: got %v, want %v", got.M, m.M)
+	}
+}
+
+func TestNewLookAtPlacesEyeAtOrigin(t *testing.T) {
+	eye := Vec3{X: 3, Y: 4, Z: 5}
+	target := Vec3{X: 0, Y: 0, Z: 0}
+	view := NewLookAt(eye, target, Vec3{Y: 1})
+
+	got, w := view.MultiplyVec3(eye)
+	if math.Abs(got.X) > 1e-9 || math.Abs(got.Y) > 1e-9 || math.Abs(got.Z) > 1e-9 {
+		t.Errorf("the eye position should map to the view-space origin, got %v (w=%v)", got, w)
+	}
+}
+
+func TestNewLookAtForwardMapsToNegativeZ(t *testing.T) {
+	eye := Vec3{X: 0, Y: 0, Z: 5}
+	target := Vec3{X: 0, Y: 0, Z: 0}
+	view := NewLookAt(eye, target, Vec3{Y: 1})
+
+	point := Vec3{X: 0, Y: 0, Z: 0} // straight ahead of the camera
+	got, _ := view.MultiplyVec3(point)
+	if got.Z >= 0 {
+		t.Errorf("a point in front of the camera should have negative view-space Z, got %v", got.Z)
+	}
+}
@@ -0,0 +1,58 @@
+package vector
+
+import "math"
+
+// Quaternion represents an orientation or rotation, avoiding the gimbal
+// lock that Euler angles suffer from.
+type Quaternion struct {
+	X, Y, Z, W float64
+}
+
+// NewIdentityQuaternion returns the "no rotation" quaternion.
+func NewIdentityQuaternion() Quaternion {
+	return Quaternion{W: 1}
+}
+
+// FromAxisAngle builds a quaternion rotating by angle radians around axis.
+func FromAxisAngle(axis Vec3, angle float64) Quaternion {
+	axis = axis.Normalize()
+	half := angle / 2
+	s := math.Sin(half)
+	return Quaternion{X: axis.X * s, Y: axis.Y * s, Z: axis.Z * s, W: math.Cos(half)}
+}
+
+// Mul composes two rotations: q.Mul(other) applies other first, then q.
+func (q Quaternion) Mul(other Quaternion) Quaternion {
+	return Quaternion{
+		W: q.W*other.W - q.X*other.X - q.Y*other.Y - q.Z*other.Z,
+		X: q.W*other.X + q.X*other.W + q.Y*other.Z - q.Z*other.Y,
+		Y: q.W*other.Y - q.X*other.Z + q.Y*other.W + q.Z*other.X,
+		Z: q.W*other.Z + q.X*other.Y - q.Y*other.X + q.Z*other.W,
+	}
+}
+
+// Normalize returns q scaled to unit length, or the identity quaternion if
+// q is itself zero-length.
+func (q Quaternion) Normalize() Quaternion {
+	length := math.Sqrt(q.X*q.X + q.Y*q.Y + q.Z*q.Z + q.W*q.W)
+	if length == 0 {
+		return NewIdentityQuaternion()
+	}
+	inv := 1 / length
+	return Quaternion{X: q.X * inv, Y: q.Y * inv, Z: q.Z * inv, W: q.W * inv}
+}
+
+// ToMat4 converts q to the equivalent rotation matrix, usable the same way
+// as NewRotationX/Y/Z with MultiplyVec3.
+func (q Quaternion) ToMat4() Mat4x4 {
+	x, y, z, w := q.X, q.Y, q.Z, q.W
+
+	return Mat4x4{
+		M: [16]float64{
+			1 - 2*(y*y+z*z), 2 * (x*y + w*z), 2 * (x*z - w*y), 0,
+			2 * (x*y - w*z), 1 - 2*(x*x+z*z), 2 * (y*z + w*x), 0,
+			2 * (x*z + w*y), 2 * (y*z - w*x), 1 - 2*(x*x+y*y), 0,
+			0, 0, 0, 1,
+		},
+	}
+}
@@ -0,0 +1,71 @@
+package vector
+
+import (
+	"math"
+	"testing"
+)
+
+func approxEqualVec3(a, b Vec3, eps float64) bool {
+	return math.Abs(a.X-b.X) <= eps && math.Abs(a.Y-b.Y) <= eps && math.Abs(a.Z-b.Z) <= eps
+}
+
+func TestFromAxisAngleMatchesNewRotationX(t *testing.T) {
+	angle := 0.7
+	got := FromAxisAngle(Vec3{X: 1}, angle).ToMat4()
+	want := NewRotationX(angle)
+	if !approxEqualMat4x4(got, want, 1e-9) {
+		t.Errorf("got %v, want %v", got.M, want.M)
+	}
+}
+
+func TestFromAxisAngleMatchesNewRotationY(t *testing.T) {
+	angle := -1.2
+	got := FromAxisAngle(Vec3{Y: 1}, angle).ToMat4()
+	want := NewRotationY(angle)
+	if !approxEqualMat4x4(got, want, 1e-9) {
+		t.Errorf("got %v, want %v", got.M, want.M)
+	}
+}
+
+func TestFromAxisAngleMatchesNewRotationZ(t *testing.T) {
+	angle := 2.4
+	got := FromAxisAngle(Vec3{Z: 1}, angle).ToMat4()
+	want := NewRotationZ(angle)
+	if !approxEqualMat4x4(got, want, 1e-9) {
+		t.Errorf("got %v, want %v", got.M, want.M)
+	}
+}
+
+func TestMulComposesRotations(t *testing.T) {
+	q1 := FromAxisAngle(Vec3{Y: 1}, 0.4)
+	q2 := FromAxisAngle(Vec3{X: 1}, 0.2)
+
+	v := Vec3{Z: -1}
+	viaQuat, _ := q1.Mul(q2).ToMat4().MultiplyVec3(v)
+	viaMat, _ := q1.ToMat4().Multiply(q2.ToMat4()).MultiplyVec3(v)
+
+	if !approxEqualVec3(viaQuat, viaMat, 1e-9) {
+		t.Errorf("q1.Mul(q2) should apply q2 then q1: got %v, want %v", viaQuat, viaMat)
+	}
+}
+
+func TestNormalizeProducesUnitQuaternion(t *testing.T) {
+	q := Quaternion{X: 1, Y: 2, Z: 3, W: 4}.Normalize()
+	length := math.Sqrt(q.X*q.X + q.Y*q.Y + q.Z*q.Z + q.W*q.W)
+	if math.Abs(length-1) > 1e-12 {
+		t.Errorf("got length %v, want 1", length)
+	}
+}
+
+func TestNormalizeZeroQuaternionReturnsIdentity(t *testing.T) {
+	got := Quaternion{}.Normalize()
+	if got != NewIdentityQuaternion() {
+		t.Errorf("got %v, want identity", got)
+	}
+}
+
+func TestIdentityQuaternionToMat4IsIdentity(t *testing.T) {
+	if got := NewIdentityQuaternion().ToMat4(); !approxEqualMat4x4(got, identity(), 1e-12) {
+		t.Errorf("got %v, want identity", got.M)
+	}
+}
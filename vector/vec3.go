@@ -1,5 +1,7 @@
 package vector
 
+import "math"
+
 // Vec3 represents a point or vector in 3D space.
 type Vec3 struct {
 	X, Y, Z float64
@@ -8,3 +10,37 @@ type Vec3 struct {
 func (v1 Vec3) Add(v2 Vec3) Vec3 {
 	return Vec3{X: v1.X + v2.X, Y: v1.Y + v2.Y, Z: v1.Z + v2.Z}
 }
+
+func (v1 Vec3) Sub(v2 Vec3) Vec3 {
+	return Vec3{X: v1.X - v2.X, Y: v1.Y - v2.Y, Z: v1.Z - v2.Z}
+}
+
+func (v Vec3) Scale(s float64) Vec3 {
+	return Vec3{X: v.X * s, Y: v.Y * s, Z: v.Z * s}
+}
+
+func (v1 Vec3) Dot(v2 Vec3) float64 {
+	return v1.X*v2.X + v1.Y*v2.Y + v1.Z*v2.Z
+}
+
+func (v1 Vec3) Cross(v2 Vec3) Vec3 {
+	return Vec3{
+		X: v1.Y*v2.Z - v1.Z*v2.Y,
+		Y: v1.Z*v2.X - v1.X*v2.Z,
+		Z: v1.X*v2.Y - v1.Y*v2.X,
+	}
+}
+
+func (v Vec3) Length() float64 {
+	return math.Sqrt(v.Dot(v))
+}
+
+// Normalize returns v scaled to unit length, or the zero vector if v is
+// itself zero-length.
+func (v Vec3) Normalize() Vec3 {
+	length := v.Length()
+	if length == 0 {
+		return Vec3{}
+	}
+	return v.Scale(1 / length)
+}
@@ -0,0 +1,50 @@
+package vector
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCrossOrientation(t *testing.T) {
+	x := Vec3{X: 1}
+	y := Vec3{Y: 1}
+	want := Vec3{Z: 1}
+	if got := x.Cross(y); got != want {
+		t.Fatalf("X cross Y = %v, want %v", got, want)
+	}
+	if got := y.Cross(x); got != want.Scale(-1) {
+		t.Fatalf("Y cross X = %v, want %v", got, want.Scale(-1))
+	}
+}
+
+func TestNormalizeZeroVectorReturnsZero(t *testing.T) {
+	if got := (Vec3{}).Normalize(); got != (Vec3{}) {
+		t.Fatalf("Normalize of zero vector = %v, want zero vector", got)
+	}
+}
+
+func TestNormalizeScalesToUnitLength(t *testing.T) {
+	got := (Vec3{X: 3, Y: 4}).Normalize()
+	want := Vec3{X: 0.6, Y: 0.8}
+	const eps = 1e-9
+	if math.Abs(got.X-want.X) > eps || math.Abs(got.Y-want.Y) > eps || math.Abs(got.Z-want.Z) > eps {
+		t.Fatalf("Normalize = %v, want %v", got, want)
+	}
+	if length := got.Length(); math.Abs(length-1) > eps {
+		t.Errorf("normalized length = %v, want 1", length)
+	}
+}
+
+func TestDot(t *testing.T) {
+	a := Vec3{X: 1, Y: 2, Z: 3}
+	b := Vec3{X: 4, Y: -5, Z: 6}
+	if got, want := a.Dot(b), 12.0; got != want {
+		t.Fatalf("Dot = %v, want %v", got, want)
+	}
+}
+
+func TestLength(t *testing.T) {
+	if got, want := (Vec3{X: 3, Y: 4}).Length(), 5.0; got != want {
+		t.Fatalf("Length = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,75 @@
+package vector
+
+// Vec4 is a homogeneous point or vector, typically a clip-space position.
+type Vec4 struct {
+	X, Y, Z, W float64
+}
+
+func (v1 Vec4) Add(v2 Vec4) Vec4 {
+	return Vec4{X: v1.X + v2.X, Y: v1.Y + v2.Y, Z: v1.Z + v2.Z, W: v1.W + v2.W}
+}
+
+func (v1 Vec4) Sub(v2 Vec4) Vec4 {
+	return Vec4{X: v1.X - v2.X, Y: v1.Y - v2.Y, Z: v1.Z - v2.Z, W: v1.W - v2.W}
+}
+
+func (v Vec4) Scale(s float64) Vec4 {
+	return Vec4{X: v.X * s, Y: v.Y * s, Z: v.Z * s, W: v.W * s}
+}
+
+// LerpVec4 linearly interpolates from a to b by t (0 returns a, 1 returns b).
+func LerpVec4(a, b Vec4, t float64) Vec4 {
+	return a.Add(b.Sub(a).Scale(t))
+}
+
+// insideNearPlane reports whether v is on the visible side of the near
+// clip plane, offset from the standard z >= -w by near.
+func insideNearPlane(v Vec4, near float64) bool {
+	return v.Z >= -v.W+near
+}
+
+// intersectNearPlane finds where the edge from inside to outside crosses
+// the standard clip-space plane z == -w. The near offset that
+// insideNearPlane applies is not used here, so for near > 0 the returned
+// point lies on z == -w rather than on the offset plane.
+func intersectNearPlane(inside, outside Vec4) Vec4 {
+	insideD := inside.Z + inside.W
+	outsideD := outside.Z + outside.W
+	t := insideD / (insideD - outsideD)
+	return LerpVec4(inside, outside, t)
+}
+
+// ClipTriangleNearPlane clips the triangle v1,v2,v3 (in clip space) against
+// the near plane using Sutherland-Hodgman polygon clipping, and re-fans the
+// resulting 0-4 vertex polygon into triangles. A fully visible triangle is
+// returned unchanged; a fully invisible one yields nil.
+func ClipTriangleNearPlane(v1, v2, v3 Vec4, near float64) [][3]Vec4 {
+	verts := [3]Vec4{v1, v2, v3}
+
+	var poly []Vec4
+	for i := 0; i < len(verts); i++ {
+		cur := verts[i]
+		prev := verts[(i+len(verts)-1)%len(verts)]
+		curIn := insideNearPlane(cur, near)
+		prevIn := insideNearPlane(prev, near)
+
+		switch {
+		case curIn && prevIn:
+			poly = append(poly, cur)
+		case curIn && !prevIn:
+			poly = append(poly, intersectNearPlane(cur, prev), cur)
+		case !curIn && prevIn:
+			poly = append(poly, intersectNearPlane(prev, cur))
+		}
+	}
+
+	if len(poly) < 3 {
+		return nil
+	}
+
+	triangles := make([][3]Vec4, 0, len(poly)-2)
+	for i := 1; i < len(poly)-1; i++ {
+		triangles = append(triangles, [3]Vec4{poly[0], poly[i], poly[i+1]})
+	}
+	return triangles
+}
@@ -0,0 +1,67 @@
+package vector
+
+import "testing"
+
+func TestClipTriangleNearPlaneAllCombinations(t *testing.T) {
+	const near = 0                           // plane is z == -w, i.e. z == -1 for these w=1 vertices
+	inside := Vec4{X: 0, Y: 0, Z: 0, W: 1}   // z >= -w
+	outside := Vec4{X: 0, Y: 0, Z: -2, W: 1} // z < -w
+
+	pick := func(in bool) Vec4 {
+		if in {
+			return inside
+		}
+		return outside
+	}
+
+	cases := []struct {
+		name               string
+		in1, in2, in3      bool
+		wantTriangleCount  int
+		wantFullyUnchanged bool
+	}{
+		{"all inside", true, true, true, 1, true},
+		{"v1 outside", false, true, true, 2, false},
+		{"v2 outside", true, false, true, 2, false},
+		{"v3 outside", true, true, false, 2, false},
+		{"only v1 inside", true, false, false, 1, false},
+		{"only v2 inside", false, true, false, 1, false},
+		{"only v3 inside", false, false, true, 1, false},
+		{"all outside", false, false, false, 0, false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v1, v2, v3 := pick(c.in1), pick(c.in2), pick(c.in3)
+			got := ClipTriangleNearPlane(v1, v2, v3, near)
+			if len(got) != c.wantTriangleCount {
+				t.Fatalf("got %d triangles, want %d", len(got), c.wantTriangleCount)
+			}
+			if c.wantFullyUnchanged {
+				want := [3]Vec4{v1, v2, v3}
+				if got[0] != want {
+					t.Fatalf("fully-inside triangle should pass through unchanged: got %v, want %v", got[0], want)
+				}
+			}
+			for _, tri := range got {
+				for _, v := range tri {
+					if !insideNearPlane(v, near) {
+						// Allow for floating point noise right at the boundary.
+						const eps = 1e-9
+						if v.Z+v.W < -eps {
+							t.Errorf("clipped vertex %v lies outside the near plane", v)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestClipTriangleNearPlaneFullyOutsideReturnsNil(t *testing.T) {
+	v := Vec4{X: 0, Y: 0, Z: -5, W: 1}
+	got := ClipTriangleNearPlane(v, v, v, 0)
+	if got != nil {
+		t.Fatalf("expected nil for a fully outside triangle, got %v", got)
+	}
+}